@@ -15,11 +15,13 @@ import (
 //	  *clientx.API
 //	}
 type API struct {
-	httpClient *http.Client
-	options    *Options
-	retry      Retrier
-	limiter    Limiter
-	breaker    *CircuitBreaker
+	httpClient  *http.Client
+	doer        Doer
+	options     *Options
+	retry       Retrier
+	limiter     Limiter
+	breaker     *CircuitBreaker
+	concurrency *ConcurrencyLimiter
 }
 
 type (
@@ -36,6 +38,9 @@ type (
 		RateLimit        *OptionRateLimit
 		Retry            *OptionRetry
 		CircuitBreaker   *OptionCircuitBreaker
+		Concurrency      *OptionConcurrency
+		// Middlewares are applied around httpClient.Do, outermost first.
+		Middlewares []RoundTripMiddleware
 	}
 
 	OptionRateLimit struct {
@@ -53,6 +58,14 @@ type (
 		Conditions []RetryCond
 		// Retry function which will be used as main retry logic.
 		Fn RetryFunc
+		// RetryAfterHeader is an additional response header, beyond the
+		// standard Retry-After, that servers may use to signal how long to
+		// wait before the next retry (e.g. "X-RateLimit-Reset").
+		RetryAfterHeader string
+		// PerAttemptTimeout, if set, bounds each individual retry attempt
+		// with its own context derived from the request's context, instead
+		// of letting every attempt share the outer deadline.
+		PerAttemptTimeout time.Duration
 	}
 
 	OptionCircuitBreaker struct {
@@ -60,6 +73,12 @@ type (
 		ConsecutiveFailuresLimit uint32
 		BreakerTimeOutInSeconds  uint32
 	}
+
+	OptionConcurrency struct {
+		MaxConcurrentRequests int
+		ShouldThrottle        ConcurrencyThrottleCond
+		OnMetrics             ConcurrencyMetricsFn
+	}
 )
 
 // NewAPI returns new base API structure with preselected http.DefaultClient
@@ -92,6 +111,7 @@ func NewAPI(opts ...Option) *API {
 		httpClient: options.HttpClient,
 		options:    options,
 	}
+	api.doer = chainMiddleware(api.httpClient, options.Middlewares)
 	if options.Retry != nil {
 		api.retry = &backoff{
 			minWaitTime: options.Retry.MinWaitTime,
@@ -112,6 +132,14 @@ func NewAPI(opts ...Option) *API {
 		api.breaker = newCircuitBreaker(options.CircuitBreaker)
 	}
 
+	if options.Concurrency != nil {
+		api.concurrency = newConcurrencyLimiter(
+			int64(options.Concurrency.MaxConcurrentRequests),
+			options.Concurrency.ShouldThrottle,
+			options.Concurrency.OnMetrics,
+		)
+	}
+
 	return api
 }
 
@@ -145,13 +173,40 @@ func WithRetry(maxAttempts int, minWaitTime, maxWaitTime time.Duration, f RetryF
 		if f == nil {
 			f = ExponentalBackoff // uses as default
 		}
-		o.Retry = &OptionRetry{
-			MaxAttempts: maxAttempts,
-			MinWaitTime: minWaitTime,
-			MaxWaitTime: maxWaitTime,
-			Conditions:  conditions,
-			Fn:          f,
+		if o.Retry == nil {
+			o.Retry = &OptionRetry{}
+		}
+		o.Retry.MaxAttempts = maxAttempts
+		o.Retry.MinWaitTime = minWaitTime
+		o.Retry.MaxWaitTime = maxWaitTime
+		o.Retry.Conditions = conditions
+		o.Retry.Fn = f
+	}
+}
+
+// WithRetryAfterHeader configures an additional response header, beyond the
+// standard Retry-After, that the retry mechanism will check for a
+// server-provided minimum delay before the next attempt. It does not enable
+// retries by itself: use it together with WithRetry, in either order.
+func WithRetryAfterHeader(header string) Option {
+	return func(o *Options) {
+		if o.Retry == nil {
+			o.Retry = &OptionRetry{}
 		}
+		o.Retry.RetryAfterHeader = header
+	}
+}
+
+// WithPerAttemptTimeout bounds each retry attempt with its own context
+// timeout derived from the request's context, rather than letting every
+// attempt share the outer deadline. It does not enable retries by itself:
+// use it together with WithRetry, in either order.
+func WithPerAttemptTimeout(d time.Duration) Option {
+	return func(o *Options) {
+		if o.Retry == nil {
+			o.Retry = &OptionRetry{}
+		}
+		o.Retry.PerAttemptTimeout = d
 	}
 }
 
@@ -186,6 +241,32 @@ func WithHeaderSet(headers map[string][]string) Option {
 	}
 }
 
+// WithMiddleware registers RoundTripMiddleware around httpClient.Do, in
+// addition to any previously registered middleware. The first middleware
+// given is the outermost: it sees the outgoing request first and the
+// incoming response last.
+func WithMiddleware(mws ...RoundTripMiddleware) Option {
+	return func(o *Options) {
+		o.Middlewares = append(o.Middlewares, mws...)
+	}
+}
+
+// WithMaxConcurrentRequests caps the number of in-flight requests using a
+// counting semaphore, independent of the rate limiter (which paces req/sec).
+// When a response matches shouldThrottle (503 by default, if nil), the
+// ceiling is halved and ramped back up as successful responses arrive; see
+// ConcurrencyLimiter. onMetrics, if non-nil, is invoked after every
+// acquire/release with the current limit, in-flight count and wait time.
+func WithMaxConcurrentRequests(n int, shouldThrottle ConcurrencyThrottleCond, onMetrics ConcurrencyMetricsFn) Option {
+	return func(o *Options) {
+		o.Concurrency = &OptionConcurrency{
+			MaxConcurrentRequests: n,
+			ShouldThrottle:        shouldThrottle,
+			OnMetrics:             onMetrics,
+		}
+	}
+}
+
 func WithCircuitBreaker(name string, breakerTimeOutInSeconds uint32, consecutiveFailuresLimit uint32) Option {
 	return func(o *Options) {
 		o.CircuitBreaker = &OptionCircuitBreaker{
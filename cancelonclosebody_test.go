@@ -0,0 +1,35 @@
+package clientx
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCancelOnCloseBody_ClosingCancelsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	body := &cancelOnCloseBody{ReadCloser: io.NopCloser(strings.NewReader("payload")), cancel: cancel}
+
+	if ctx.Err() != nil {
+		t.Fatal("context should still be live before Close")
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+	if string(data) != "payload" {
+		t.Fatalf("got %q, want %q", data, "payload")
+	}
+	if ctx.Err() != nil {
+		t.Fatal("context must stay live while the body is being read, not cancelled until Close")
+	}
+
+	if err := body.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("expected Close to cancel the wrapped context")
+	}
+}
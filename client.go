@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/sony/gobreaker/v2"
+	"golang.org/x/time/rate"
 	"io"
 	"net/http"
 	"net/http/httputil"
@@ -25,7 +26,48 @@ func (c *client[Req, Resp]) do(ctx context.Context, req *RequestBuilder[Req, Res
 		return nil, nil, err
 	}
 
-	// Create HTTP request and apply beforeResponse chain
+	// Cap the number of in-flight requests. This is orthogonal to the rate
+	// limiter above, which paces requests per second rather than bounding
+	// concurrency.
+	if c.api.concurrency != nil {
+		if err := c.api.concurrency.Acquire(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	resp, data, err := c.doRequest(ctx, req, decode, enc)
+
+	c.applyRateLimitHints(resp)
+
+	if c.api.concurrency != nil {
+		c.api.concurrency.Release(resp, err)
+	}
+
+	return resp, data, err
+}
+
+// applyRateLimitHints feeds resp through the user-supplied
+// Options.RateLimitParseFn, if any, and pushes the parsed limit/burst
+// forward into the limiter so it takes effect at resetAt.
+func (c *client[Req, Resp]) applyRateLimitHints(resp *http.Response) {
+	if resp == nil || c.api.options.RateLimitParseFn == nil {
+		return
+	}
+	limit, remaining, resetAt, err := c.api.options.RateLimitParseFn(resp)
+	if err != nil {
+		return
+	}
+
+	if limit > 0 {
+		if window := time.Until(resetAt); window > 0 {
+			c.api.limiter.SetLimitAt(resetAt, rate.Every(window/time.Duration(limit)))
+		}
+	}
+	c.api.limiter.SetBurstAt(resetAt, remaining)
+}
+
+func (c *client[Req, Resp]) doRequest(ctx context.Context, req *RequestBuilder[Req, Resp], decode bool, enc EncoderDecoder) (*http.Response, *Resp, error) {
+	// Create HTTP request and apply beforeRequest chain
 	httpReq, err := c.makeRequest(ctx, req, enc)
 	if err != nil {
 		return nil, nil, err
@@ -35,6 +77,13 @@ func (c *client[Req, Resp]) do(ctx context.Context, req *RequestBuilder[Req, Res
 	if err != nil {
 		return nil, nil, err
 	}
+
+	for _, after := range req.afterResponse {
+		if err := after(resp); err != nil {
+			return nil, nil, errors.Wrap(err, "after response hook failed")
+		}
+	}
+
 	r, err := responseReader(resp)
 	if err != nil {
 		return nil, nil, err
@@ -83,7 +132,11 @@ func (c *client[Req, Resp]) makeRequest(ctx context.Context, req *RequestBuilder
 		}
 	}
 	if len(c.api.options.Headers) != 0 {
-		httpReq.Header = c.api.options.Headers
+		// Clone rather than alias: every logical request mutates its own
+		// headers below (idempotency key, multipart Content-Type, ...), and
+		// writing those into the shared Options.Headers map would leak
+		// across requests and race under concurrent use.
+		httpReq.Header = c.api.options.Headers.Clone()
 	}
 
 	// Apply options to request
@@ -93,19 +146,48 @@ func (c *client[Req, Resp]) makeRequest(ctx context.Context, req *RequestBuilder
 		}
 	}
 
+	// Run the typed BeforeRequest chain last, once the request is fully
+	// built, so hooks like an OAuth token refresher see the final headers
+	// and body set by RequestOptions.
+	for _, before := range req.beforeRequest {
+		if err := before(httpReq); err != nil {
+			return nil, err
+		}
+	}
+
 	return httpReq, nil
 }
 
 func (c *client[Req, Resp]) performRequest(ctx context.Context, httpReq *http.Request, req *RequestBuilder[Req, Resp]) (*http.Response, error) {
-	do := func(c *client[Req, Resp], req *http.Request, reuse bool) (*http.Response, error) {
-		if reuse && req.Body != nil {
-			// Issue https://github.com/golang/go/issues/36095
-			var b bytes.Buffer
-			b.ReadFrom(req.Body)
-			req.Body = io.NopCloser(&b)
-
-			cloneReq := req.Clone(ctx)
-			cloneReq.Body = io.NopCloser(bytes.NewReader(b.Bytes()))
+	var perAttemptTimeout time.Duration
+	if c.api.options.Retry != nil {
+		perAttemptTimeout = c.api.options.Retry.PerAttemptTimeout
+	}
+	bodyProvider := req.BodyProvider
+
+	if httpReq.Header.Get(IdempotencyKeyHeader) == "" {
+		httpReq.Header.Set(IdempotencyKeyHeader, newIdempotencyKey())
+	}
+
+	do := func(c *client[Req, Resp], req *http.Request, reuse bool, attemptCtx context.Context) (*http.Response, error) {
+		if reuse {
+			cloneReq := req.Clone(attemptCtx)
+			switch {
+			case bodyProvider != nil:
+				// Re-stream the body from its source (e.g. disk) instead
+				// of buffering it into memory on every attempt.
+				body, err := bodyProvider()
+				if err != nil {
+					return nil, err
+				}
+				cloneReq.Body = body
+			case req.Body != nil:
+				// Issue https://github.com/golang/go/issues/36095
+				var b bytes.Buffer
+				b.ReadFrom(req.Body)
+				req.Body = io.NopCloser(&b)
+				cloneReq.Body = io.NopCloser(bytes.NewReader(b.Bytes()))
+			}
 			req = cloneReq
 		}
 
@@ -113,13 +195,13 @@ func (c *client[Req, Resp]) performRequest(ctx context.Context, httpReq *http.Re
 		var err error
 
 		if c.api.breaker == nil || c.api.breaker.Breaker == nil {
-			resp, err = c.api.httpClient.Do(req)
+			resp, err = c.api.doer.Do(req)
 			if err != nil {
 				return nil, err
 			}
 		} else {
 			resp, err = c.api.breaker.Breaker.Execute(func() (*http.Response, error) {
-				resp, err := c.api.httpClient.Do(req)
+				resp, err := c.api.doer.Do(req)
 				if err != nil {
 					return nil, err
 				}
@@ -146,13 +228,40 @@ func (c *client[Req, Resp]) performRequest(ctx context.Context, httpReq *http.Re
 		}
 		return resp, nil
 	}
-	if c.api.retry == nil {
-		// Do single request without using backoff retry mechanism
-		return do(c, httpReq, false)
+	if c.api.retry == nil || !isRetryable(httpReq.Method, req.Idempotent) || !isReplayable(httpReq.Body) {
+		// Do single request without using backoff retry mechanism: either
+		// retry isn't configured, replaying this request isn't known to be
+		// safe (a non-idempotent method the caller hasn't opted into), or
+		// the body itself can't be replayed (e.g. WithRequestMultipart's
+		// single-pass pipe).
+		return do(c, httpReq, false, ctx)
 	}
 
+	// api.retry is shared across every logical request made through this
+	// client, so it must start from a clean slate here rather than only
+	// between distinct retry loops.
+	c.api.retry.Reset()
+
 	for {
-		resp, err := do(c, httpReq, true)
+		attemptCtx := ctx
+		var cancelAttempt context.CancelFunc
+		var attemptTimer *time.Timer
+		if perAttemptTimeout > 0 {
+			// Use a manually-armed cancel instead of context.WithTimeout so
+			// a successful final attempt can disarm the timer (below)
+			// without cancelling attemptCtx out from under the response
+			// body the caller is about to read.
+			attemptCtx, cancelAttempt = context.WithCancel(ctx)
+			attemptTimer = time.AfterFunc(perAttemptTimeout, cancelAttempt)
+		}
+
+		resp, err := do(c, httpReq, true, attemptCtx)
+
+		if d, ok := parseRetryAfter(resp, c.api.options.Retry.RetryAfterHeader); ok {
+			if setter, ok := c.api.retry.(retryAfterAware); ok {
+				setter.setMinNextDelay(d)
+			}
+		}
 
 		var isMatchedCond bool
 		for _, cond := range c.api.options.Retry.Conditions {
@@ -162,6 +271,21 @@ func (c *client[Req, Resp]) performRequest(ctx context.Context, httpReq *http.Re
 			}
 		}
 		if isMatchedCond {
+			// Disarm this attempt's timer. If no retry follows, resp is
+			// returned to the caller below, so its context stays live until
+			// resp.Body is closed; it is cancelled right away only once a
+			// retry is certain (just before sleeping).
+			if attemptTimer != nil {
+				attemptTimer.Stop()
+			}
+			if cancelAttempt != nil {
+				if resp != nil {
+					resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancelAttempt}
+				} else {
+					cancelAttempt()
+				}
+			}
+
 			// Get next duration interval, sleep and make another request
 			// till nextDuration != stopBackoff
 			nextDuration := c.api.retry.Next()
@@ -169,15 +293,58 @@ func (c *client[Req, Resp]) performRequest(ctx context.Context, httpReq *http.Re
 				c.api.retry.Reset()
 				return resp, err
 			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < nextDuration {
+				// Waiting the full backoff would outlive the caller's
+				// deadline anyway, so stop retrying now instead of sleeping
+				// partway and failing on the next attempt regardless.
+				return resp, err
+			}
+			if cancelAttempt != nil {
+				// Retrying: this attempt's response is discarded, so its
+				// per-attempt context can be released now.
+				cancelAttempt()
+			}
 			time.Sleep(nextDuration)
 			continue
 		}
 
-		// Break retries mechanism if conditions weren't matched
+		// Break retries mechanism if conditions weren't matched. Disarm the
+		// per-attempt timer (if any) without cancelling attemptCtx itself:
+		// the caller reads resp.Body, which is bound to attemptCtx, after we
+		// return, and the timer firing later would abort that read out from
+		// under them. cancelAttempt itself is not dropped: it's deferred to
+		// resp.Body.Close so attemptCtx is freed once the caller is done
+		// reading instead of leaking until the outer ctx ends.
+		if attemptTimer != nil {
+			attemptTimer.Stop()
+		}
+		if cancelAttempt != nil {
+			if resp != nil {
+				resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancelAttempt}
+			} else {
+				// No body to defer the cancel to (the attempt itself
+				// failed), so release attemptCtx right away.
+				cancelAttempt()
+			}
+		}
 		return resp, err
 	}
 }
 
+// cancelOnCloseBody wraps a response body so that closing it also cancels
+// the per-attempt context the body is bound to, freeing attemptCtx once the
+// caller is done reading instead of leaking it until the outer ctx ends.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 func (c *client[Req, Resp]) buildRequestURL(resource string) (*url.URL, error) {
 	u, err := url.Parse(c.api.options.BaseURL)
 	if err != nil {
@@ -0,0 +1,146 @@
+package clientx
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ConcurrencyMetrics is a snapshot of a ConcurrencyLimiter's state, reported
+// after every acquire/release so callers can observe how it is adapting.
+type ConcurrencyMetrics struct {
+	// Limit is the current effective concurrency ceiling.
+	Limit int64
+	// InFlight is the number of requests currently holding a slot.
+	InFlight int64
+	// WaitTime is how long the most recent Acquire call blocked, zero for
+	// metrics reported from Release.
+	WaitTime time.Duration
+}
+
+// ConcurrencyMetricsFn receives a ConcurrencyMetrics snapshot.
+type ConcurrencyMetricsFn func(ConcurrencyMetrics)
+
+// ConcurrencyThrottleCond reports whether resp/err should be treated as a
+// signal to throttle back the effective concurrency ceiling, e.g. a 503.
+type ConcurrencyThrottleCond func(resp *http.Response, err error) bool
+
+// ConcurrencyLimiter caps the number of in-flight requests performed
+// through client.do. Unlike Limiter, which paces requests per second, it
+// bounds how many requests may be outstanding at once, which prevents
+// connection storms against fragile upstreams.
+//
+// When a response matches its throttle condition (503 by default), the
+// effective ceiling is halved; it is then ramped back up additively as
+// successful responses arrive (additive-increase/multiplicative-decrease),
+// the same technique Arvados' requestLimiter uses. A last503 timestamp
+// debounces the halving so a burst of 503s doesn't repeatedly collapse the
+// ceiling.
+type ConcurrencyLimiter struct {
+	mu      sync.Mutex
+	waiters []chan struct{}
+
+	maxLimit     int64
+	currentLimit int64
+	inFlight     int64
+
+	shouldThrottle ConcurrencyThrottleCond
+	last503        time.Time
+	rampCooldown   time.Duration
+
+	onMetrics ConcurrencyMetricsFn
+}
+
+func newConcurrencyLimiter(max int64, shouldThrottle ConcurrencyThrottleCond, onMetrics ConcurrencyMetricsFn) *ConcurrencyLimiter {
+	if max < 1 {
+		max = 1
+	}
+	if shouldThrottle == nil {
+		shouldThrottle = func(resp *http.Response, err error) bool {
+			return resp != nil && resp.StatusCode == http.StatusServiceUnavailable
+		}
+	}
+	return &ConcurrencyLimiter{
+		maxLimit:       max,
+		currentLimit:   max,
+		shouldThrottle: shouldThrottle,
+		rampCooldown:   time.Second,
+		onMetrics:      onMetrics,
+	}
+}
+
+// Acquire blocks until a concurrency slot is available or ctx is done.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context) error {
+	start := time.Now()
+	for {
+		l.mu.Lock()
+		if l.inFlight < l.currentLimit {
+			l.inFlight++
+			l.mu.Unlock()
+			l.report(time.Since(start))
+			return nil
+		}
+		wake := make(chan struct{})
+		l.waiters = append(l.waiters, wake)
+		l.mu.Unlock()
+
+		select {
+		case <-wake:
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release returns a concurrency slot and adapts the effective ceiling based
+// on resp/err.
+func (l *ConcurrencyLimiter) Release(resp *http.Response, err error) {
+	l.mu.Lock()
+	l.inFlight--
+	l.adapt(resp, err)
+	waiters := l.waiters
+	l.waiters = nil
+	l.mu.Unlock()
+
+	for _, wake := range waiters {
+		close(wake)
+	}
+	l.report(0)
+}
+
+// adapt must be called with l.mu held.
+func (l *ConcurrencyLimiter) adapt(resp *http.Response, err error) {
+	if l.shouldThrottle(resp, err) {
+		if time.Since(l.last503) < l.rampCooldown {
+			// Still ramping down from a previous 503; don't halve again
+			// for every response in the same burst.
+			return
+		}
+		l.last503 = time.Now()
+		l.currentLimit /= 2
+		if l.currentLimit < 1 {
+			l.currentLimit = 1
+		}
+		return
+	}
+
+	if err != nil || resp == nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Not a successful response: neither halve nor ramp up.
+		return
+	}
+	if l.currentLimit < l.maxLimit {
+		l.currentLimit++
+	}
+}
+
+func (l *ConcurrencyLimiter) report(wait time.Duration) {
+	if l.onMetrics == nil {
+		return
+	}
+	l.mu.Lock()
+	m := ConcurrencyMetrics{Limit: l.currentLimit, InFlight: l.inFlight, WaitTime: wait}
+	l.mu.Unlock()
+	l.onMetrics(m)
+}
@@ -0,0 +1,98 @@
+package clientx
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimiter_Adapt(t *testing.T) {
+	resp503 := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	resp200 := &http.Response{StatusCode: http.StatusOK}
+
+	tests := []struct {
+		name         string
+		maxLimit     int64
+		currentLimit int64
+		last503      time.Time
+		rampCooldown time.Duration
+		resp         *http.Response
+		wantLimit    int64
+	}{
+		{
+			name:         "503 halves the ceiling",
+			maxLimit:     16,
+			currentLimit: 16,
+			rampCooldown: time.Second,
+			resp:         resp503,
+			wantLimit:    8,
+		},
+		{
+			name:         "halving floors at 1, never 0",
+			maxLimit:     16,
+			currentLimit: 1,
+			rampCooldown: time.Second,
+			resp:         resp503,
+			wantLimit:    1,
+		},
+		{
+			name:         "repeated 503s within cooldown don't halve again",
+			maxLimit:     16,
+			currentLimit: 8,
+			last503:      time.Now(),
+			rampCooldown: time.Second,
+			resp:         resp503,
+			wantLimit:    8,
+		},
+		{
+			name:         "a 503 after cooldown elapses halves again",
+			maxLimit:     16,
+			currentLimit: 8,
+			last503:      time.Now().Add(-2 * time.Second),
+			rampCooldown: time.Second,
+			resp:         resp503,
+			wantLimit:    4,
+		},
+		{
+			name:         "success ramps the ceiling up by one",
+			maxLimit:     16,
+			currentLimit: 4,
+			rampCooldown: time.Second,
+			resp:         resp200,
+			wantLimit:    5,
+		},
+		{
+			name:         "success never ramps past maxLimit",
+			maxLimit:     16,
+			currentLimit: 16,
+			rampCooldown: time.Second,
+			resp:         resp200,
+			wantLimit:    16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &ConcurrencyLimiter{
+				maxLimit:     tt.maxLimit,
+				currentLimit: tt.currentLimit,
+				last503:      tt.last503,
+				rampCooldown: tt.rampCooldown,
+				shouldThrottle: func(resp *http.Response, err error) bool {
+					return resp != nil && resp.StatusCode == http.StatusServiceUnavailable
+				},
+			}
+			l.adapt(tt.resp, nil)
+			if l.currentLimit != tt.wantLimit {
+				t.Fatalf("currentLimit = %d, want %d", l.currentLimit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestNewConcurrencyLimiter_ClampsMaxToOne(t *testing.T) {
+	l := newConcurrencyLimiter(0, nil, nil)
+	if l.maxLimit != 1 || l.currentLimit != 1 {
+		t.Fatalf("maxLimit/currentLimit = %d/%d, want 1/1", l.maxLimit, l.currentLimit)
+	}
+}
@@ -0,0 +1,62 @@
+package clientx
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is set once per logical request and preserved across
+// retries, so upstreams supporting idempotency keys (Stripe-style) can
+// safely dedupe replayed attempts.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// ReaderFunc returns a fresh, unread body for a single request attempt. Set
+// it on RequestBuilder for large uploads that should be re-streamed (e.g.
+// from disk) on retry instead of being buffered into memory once and
+// replayed from there.
+type ReaderFunc func() (io.ReadCloser, error)
+
+// idempotentMethods are safe to retry by default: replaying them can't make
+// an upstream side effect happen twice.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// isRetryable reports whether a request may be safely replayed. Idempotent
+// HTTP methods are retryable by default; anything else (POST, PATCH, ...)
+// requires the caller to opt in via RequestBuilder.Idempotent.
+func isRetryable(method string, explicitlyIdempotent bool) bool {
+	return idempotentMethods[method] || explicitlyIdempotent
+}
+
+// isReplayable reports whether body can be safely re-sent on retry. A nil
+// body (GET, DELETE, ...) is always replayable. Bodies that opt out, such
+// as multipartBody's single-pass io.Pipe, are not: there is no buffered
+// copy to resend, so retrying would silently replay an empty body instead
+// of the original request.
+func isReplayable(body io.ReadCloser) bool {
+	if body == nil {
+		return true
+	}
+	_, singlePass := body.(multipartBody)
+	return !singlePass
+}
+
+// newIdempotencyKey generates a random UUIDv4 for IdempotencyKeyHeader.
+func newIdempotencyKey() string {
+	var b [16]byte
+	// crypto/rand.Read only fails if the OS entropy source is broken, which
+	// we can't recover from; fall back to the zero-value key rather than
+	// failing the request outright.
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,55 @@
+package clientx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// ensureIdempotencyKey mirrors the header-setup performRequest does before
+// its retry loop: clone the shared headers (never alias them, see
+// makeRequest), then set an idempotency key only if one isn't already
+// present.
+func ensureIdempotencyKey(shared http.Header) *http.Request {
+	req := &http.Request{Header: shared.Clone()}
+	if req.Header.Get(IdempotencyKeyHeader) == "" {
+		req.Header.Set(IdempotencyKeyHeader, newIdempotencyKey())
+	}
+	return req
+}
+
+func TestIdempotencyKey_DistinctAcrossLogicalRequests(t *testing.T) {
+	shared := http.Header{}
+
+	first := ensureIdempotencyKey(shared)
+	second := ensureIdempotencyKey(shared)
+
+	k1 := first.Header.Get(IdempotencyKeyHeader)
+	k2 := second.Header.Get(IdempotencyKeyHeader)
+	if k1 == "" || k2 == "" {
+		t.Fatal("expected both logical requests to get an idempotency key")
+	}
+	if k1 == k2 {
+		t.Fatalf("two distinct logical requests got the same idempotency key %q; cloning shared headers must not leak a key set by one request into the next", k1)
+	}
+	if got := shared.Get(IdempotencyKeyHeader); got != "" {
+		t.Fatalf("shared Options.Headers got mutated with idempotency key %q; it must stay untouched", got)
+	}
+}
+
+func TestIdempotencyKey_PreservedAcrossRetriesOfOneRequest(t *testing.T) {
+	shared := http.Header{}
+
+	logical := ensureIdempotencyKey(shared)
+	key := logical.Header.Get(IdempotencyKeyHeader)
+
+	for attempt := 0; attempt < 3; attempt++ {
+		retryAttempt := logical.Clone(context.Background())
+		if retryAttempt.Header.Get(IdempotencyKeyHeader) == "" {
+			retryAttempt.Header.Set(IdempotencyKeyHeader, newIdempotencyKey())
+		}
+		if got := retryAttempt.Header.Get(IdempotencyKeyHeader); got != key {
+			t.Fatalf("retry attempt %d got idempotency key %q, want the original %q preserved", attempt, got, key)
+		}
+	}
+}
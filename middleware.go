@@ -0,0 +1,35 @@
+package clientx
+
+import "net/http"
+
+// Doer performs an HTTP request and returns its response, the same shape as
+// *http.Client.Do. Middleware is built around this interface so it can wrap
+// either the underlying http.Client or another middleware.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RoundTripMiddleware wraps a Doer with cross-cutting behavior (auth token
+// refresh, tracing, metrics, request-ID injection, response caching, ...)
+// and returns a Doer for the caller to invoke. Middleware registered via
+// WithMiddleware is applied around httpClient.Do inside performRequest, in
+// the order given: the first middleware passed is the outermost one, i.e.
+// it sees the request first and the response last.
+type RoundTripMiddleware func(next Doer) Doer
+
+// BeforeRequestFunc runs before a request is sent, after all RequestOptions
+// have been applied. Returning an error aborts the request.
+type BeforeRequestFunc func(req *http.Request) error
+
+// AfterResponseFunc runs after a response is received but before decoding.
+// Returning an error aborts the call with that error.
+type AfterResponseFunc func(resp *http.Response) error
+
+// chainMiddleware wraps base with mws, outermost first.
+func chainMiddleware(base Doer, mws []RoundTripMiddleware) Doer {
+	doer := base
+	for i := len(mws) - 1; i >= 0; i-- {
+		doer = mws[i](doer)
+	}
+	return doer
+}
@@ -79,7 +79,7 @@ func (l *adaptiveBucketLimiter) insertEvent(at time.Time, f func()) {
 
 func (l *adaptiveBucketLimiter) tryReset() bool {
 	now := time.Now()
-	return l.nextResetAt.Equal(now) || l.nextResetAt.After(now)
+	return !l.nextResetAt.IsZero() && !now.Before(l.nextResetAt)
 }
 
 func validateResetAt(at time.Time) time.Time {
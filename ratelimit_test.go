@@ -0,0 +1,38 @@
+package clientx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdaptiveBucketLimiter_TryReset(t *testing.T) {
+	tests := []struct {
+		name        string
+		nextResetAt time.Time
+		want        bool
+	}{
+		{"zero value never fires", time.Time{}, false},
+		{"future reset doesn't fire yet", time.Now().Add(time.Hour), false},
+		{"past reset fires", time.Now().Add(-time.Second), true},
+		{"reset exactly now fires", time.Now(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &adaptiveBucketLimiter{nextResetAt: tt.nextResetAt}
+			if got := l.tryReset(); got != tt.want {
+				t.Fatalf("tryReset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateResetAt(t *testing.T) {
+	if got := validateResetAt(time.Time{}); got.IsZero() {
+		t.Fatal("validateResetAt(zero) should fall back to time.Now(), got zero value")
+	}
+	at := time.Now().Add(time.Minute)
+	if got := validateResetAt(at); !got.Equal(at) {
+		t.Fatalf("validateResetAt(%v) = %v, want unchanged", at, got)
+	}
+}
@@ -0,0 +1,98 @@
+package clientx
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resetEpochThreshold distinguishes a delta-seconds reset value from an
+// absolute Unix timestamp: no sane rate-limit window is this many seconds
+// (~31 years), but a current Unix timestamp comfortably exceeds it (every
+// timestamp since September 2001 does).
+const resetEpochThreshold = 1_000_000_000
+
+// XRateLimitParseFn parses the widely used X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset headers for Options.
+// X-RateLimit-Reset isn't standardized across APIs: some send an absolute
+// Unix timestamp, others a number of seconds until reset. Values at or
+// above resetEpochThreshold are treated as a timestamp, everything else as
+// a delta. Use GitHubRateLimitParseFn instead if the reset is known to
+// always be absolute.
+func XRateLimitParseFn(resp *http.Response) (limit int, remaining int, resetAt time.Time, err error) {
+	return parseXRateLimitHeaders(resp, true)
+}
+
+// GitHubRateLimitParseFn parses GitHub's x-ratelimit-limit,
+// x-ratelimit-remaining and x-ratelimit-reset headers, whose reset is
+// always an absolute Unix timestamp.
+func GitHubRateLimitParseFn(resp *http.Response) (limit int, remaining int, resetAt time.Time, err error) {
+	return parseXRateLimitHeaders(resp, false)
+}
+
+func parseXRateLimitHeaders(resp *http.Response, detectDelta bool) (int, int, time.Time, error) {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("clientx: parse X-RateLimit-Limit: %w", err)
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("clientx: parse X-RateLimit-Remaining: %w", err)
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("clientx: parse X-RateLimit-Reset: %w", err)
+	}
+
+	var resetAt time.Time
+	if detectDelta && reset < resetEpochThreshold {
+		resetAt = time.Now().Add(time.Duration(reset) * time.Second)
+	} else {
+		resetAt = time.Unix(reset, 0)
+	}
+	return limit, remaining, resetAt, nil
+}
+
+// RFC9331RateLimitParseFn parses the RateLimit header standardized by
+// RFC 9331, e.g. "RateLimit: limit=100, remaining=50, reset=30", where
+// reset is always delta-seconds.
+func RFC9331RateLimitParseFn(resp *http.Response) (limit int, remaining int, resetAt time.Time, err error) {
+	fields := parseRateLimitFields(resp.Header.Get("RateLimit"))
+
+	limit, ok := fields["limit"]
+	if !ok {
+		return 0, 0, time.Time{}, fmt.Errorf("clientx: RateLimit header missing limit")
+	}
+	remaining, ok = fields["remaining"]
+	if !ok {
+		return 0, 0, time.Time{}, fmt.Errorf("clientx: RateLimit header missing remaining")
+	}
+	resetSeconds, ok := fields["reset"]
+	if !ok {
+		return 0, 0, time.Time{}, fmt.Errorf("clientx: RateLimit header missing reset")
+	}
+
+	return limit, remaining, time.Now().Add(time.Duration(resetSeconds) * time.Second), nil
+}
+
+// parseRateLimitFields parses a comma-separated list of "key=value" pairs
+// into a map of integer fields, skipping entries whose value isn't an
+// integer (e.g. RateLimit-Policy's "w=60" window unit is fine to look up
+// directly by key, but a non-numeric value like a quoted policy name isn't).
+func parseRateLimitFields(v string) map[string]int {
+	fields := make(map[string]int)
+	for _, part := range strings.Split(v, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			continue
+		}
+		fields[strings.TrimSpace(kv[0])] = n
+	}
+	return fields
+}
@@ -0,0 +1,98 @@
+package clientx
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func headerResp(h map[string]string) *http.Response {
+	resp := &http.Response{Header: make(http.Header)}
+	for k, v := range h {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func TestXRateLimitParseFn_DetectsDeltaVsEpoch(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		reset     string
+		wantDelta bool
+	}{
+		{"small value is a delta in seconds", "30", true},
+		{"value just under the epoch threshold is still a delta", "999999999", true},
+		{"current unix timestamp is an epoch", strconv.FormatInt(now.Add(time.Minute).Unix(), 10), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := headerResp(map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "50",
+				"X-RateLimit-Reset":     tt.reset,
+			})
+			_, _, resetAt, err := XRateLimitParseFn(resp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantDelta {
+				if resetAt.Before(now) || resetAt.After(now.Add(time.Duration(resetEpochThreshold)*time.Second)) {
+					t.Fatalf("resetAt = %v, want interpreted as a delta from now", resetAt)
+				}
+			} else {
+				if resetAt.Year() > now.Year()+1 {
+					t.Fatalf("resetAt = %v, want interpreted as an absolute unix timestamp", resetAt)
+				}
+			}
+		})
+	}
+}
+
+func TestGitHubRateLimitParseFn_AlwaysTreatsResetAsAbsolute(t *testing.T) {
+	resetAt := time.Now().Add(30 * time.Second).Unix()
+	resp := headerResp(map[string]string{
+		"X-RateLimit-Limit":     "60",
+		"X-RateLimit-Remaining": "10",
+		"X-RateLimit-Reset":     strconv.FormatInt(resetAt, 10),
+	})
+
+	_, _, got, err := GitHubRateLimitParseFn(resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Unix(resetAt, 0)
+	if !got.Equal(want) {
+		t.Fatalf("resetAt = %v, want %v", got, want)
+	}
+}
+
+func TestRFC9331RateLimitParseFn(t *testing.T) {
+	resp := headerResp(map[string]string{
+		"RateLimit": "limit=100, remaining=50, reset=30",
+	})
+
+	limit, remaining, resetAt, err := RFC9331RateLimitParseFn(resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limit != 100 || remaining != 50 {
+		t.Fatalf("limit/remaining = %d/%d, want 100/50", limit, remaining)
+	}
+	if window := time.Until(resetAt); window <= 0 || window > 31*time.Second {
+		t.Fatalf("resetAt = %v, want ~30s from now", resetAt)
+	}
+}
+
+func TestRFC9331RateLimitParseFn_MissingField(t *testing.T) {
+	resp := headerResp(map[string]string{
+		"RateLimit": "limit=100, remaining=50",
+	})
+
+	if _, _, _, err := RFC9331RateLimitParseFn(resp); err == nil {
+		t.Fatal("expected an error for a missing reset field")
+	}
+}
@@ -2,8 +2,9 @@ package clientx
 
 import (
 	"math"
-	"math/rand"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
 	"sync/atomic"
 	"time"
 )
@@ -11,9 +12,12 @@ import (
 // RetryCond is a condition that applies only to retry backoff mechanism.
 type RetryCond func(resp *http.Response, err error) bool
 
-// RetryFunc takes attemps number, minimal and maximal wait time for backoff.
-// Returns duration that mechanism have to wait before making a request.
-type RetryFunc func(n int, min, max time.Duration) time.Duration
+// RetryFunc takes attempt number, minimal and maximal wait time, and the
+// duration returned by the previous call (0 on the first attempt) for
+// backoff. Returns duration that mechanism have to wait before making a
+// request. Randomization must go through math/rand/v2's package-level
+// functions, which are safe for concurrent use without reseeding.
+type RetryFunc func(n int, min, max, prev time.Duration) time.Duration
 
 // Retrier is a general interface for custom retry algo implementations.
 type Retrier interface {
@@ -30,10 +34,29 @@ type backoff struct {
 	maxAttempts int64
 	attempts    int64
 	f           RetryFunc
+
+	// lastDuration is the delay returned by the previous Next() call,
+	// stored as nanoseconds, so strategies like DecorrelatedJitterBackoff
+	// can factor it into the next delay.
+	lastDuration int64
+
+	// minNextDelay is a server-provided lower bound (e.g. from Retry-After)
+	// for the next call to Next(), stored as nanoseconds. It is consumed
+	// (reset to 0) the first time Next() reads it.
+	minNextDelay int64
 }
 
 var _ Retrier = (*backoff)(nil)
 
+// retryAfterAware is implemented by Retrier implementations that can honor a
+// server-provided minimum delay before the next retry, such as a Retry-After
+// or X-RateLimit-Reset header.
+type retryAfterAware interface {
+	setMinNextDelay(time.Duration)
+}
+
+var _ retryAfterAware = (*backoff)(nil)
+
 const stopBackoff time.Duration = -1
 
 func (b *backoff) Next() time.Duration {
@@ -41,10 +64,33 @@ func (b *backoff) Next() time.Duration {
 		return stopBackoff
 	}
 	atomic.AddInt64(&b.attempts, 1)
-	return b.f(int(atomic.LoadInt64(&b.attempts)), b.minWaitTime, b.maxWaitTime)
+	prev := time.Duration(atomic.LoadInt64(&b.lastDuration))
+	delay := b.f(int(atomic.LoadInt64(&b.attempts)), b.minWaitTime, b.maxWaitTime, prev)
+
+	if min := time.Duration(atomic.SwapInt64(&b.minNextDelay, 0)); min > delay {
+		delay = min
+	}
+	if delay > b.maxWaitTime {
+		delay = b.maxWaitTime
+	}
+	atomic.StoreInt64(&b.lastDuration, int64(delay))
+	return delay
 }
 
+func (b *backoff) setMinNextDelay(d time.Duration) {
+	atomic.StoreInt64(&b.minNextDelay, int64(d))
+}
+
+// Reset zeroes the attempt counter, the remembered previous delay, and any
+// pending server-provided minNextDelay. It must be called at the start of
+// every logical request's retry loop, not just between distinct retry loops
+// sharing the same backoff, otherwise a second request starts with a stale
+// attempts/lastDuration/minNextDelay carried over from the first — e.g. a
+// Retry-After set on an attempt whose retry condition didn't match is never
+// consumed by Next() and would otherwise bleed into the next request.
 func (b *backoff) Reset() int64 {
+	atomic.StoreInt64(&b.lastDuration, 0)
+	atomic.StoreInt64(&b.minNextDelay, 0)
 	return atomic.SwapInt64(&b.attempts, 0)
 }
 
@@ -52,11 +98,45 @@ func (b *backoff) Attempt() int64 {
 	return atomic.LoadInt64(&b.attempts)
 }
 
-func ExponentalBackoff(attemptNum int, min, max time.Duration) time.Duration {
+// parseRetryAfter extracts a server-requested minimum delay from resp,
+// checking the standard Retry-After header first and, if header is
+// non-empty, a secondary header such as X-RateLimit-Reset. Retry-After may
+// be either a number of seconds or an HTTP-date, per RFC 9110 section 10.2.3.
+func parseRetryAfter(resp *http.Response, header string) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if d, ok := parseRetryAfterValue(resp.Header.Get("Retry-After")); ok {
+		return d, true
+	}
+	if header != "" {
+		if d, ok := parseRetryAfterValue(resp.Header.Get(header)); ok {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func parseRetryAfterValue(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return time.Until(at), true
+	}
+	return 0, false
+}
+
+func ExponentalBackoff(attemptNum int, min, max, _ time.Duration) time.Duration {
 	const factor = 2.0
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
 	delay := time.Duration(math.Pow(factor, float64(attemptNum)) * float64(min))
-	jitter := time.Duration(rnd.Float64() * float64(min) * float64(attemptNum))
+	jitter := time.Duration(rand.Float64() * float64(min) * float64(attemptNum))
 
 	delay = delay + jitter
 	if delay > max {
@@ -65,3 +145,44 @@ func ExponentalBackoff(attemptNum int, min, max time.Duration) time.Duration {
 
 	return delay
 }
+
+// FullJitterBackoff sleeps a random duration between 0 and the exponential
+// backoff ceiling for the current attempt, capped by max. This spreads
+// retries more evenly than ExponentalBackoff's additive jitter, at the cost
+// of sometimes retrying almost immediately.
+func FullJitterBackoff(attemptNum int, min, max, _ time.Duration) time.Duration {
+	ceiling := time.Duration(math.Pow(2, float64(attemptNum)) * float64(min))
+	if ceiling > max {
+		ceiling = max
+	}
+	return randBetween(0, ceiling)
+}
+
+// DecorrelatedJitterBackoff implements the "decorrelated jitter" strategy:
+// sleep_n = min(max, random_between(min, prev*3)), starting from prev = min
+// on the first attempt. Carrying prev forward (rather than deriving it from
+// attemptNum alone) avoids the correlated retry storms that plain
+// exponential backoff with independent jitter can produce.
+func DecorrelatedJitterBackoff(attemptNum int, min, max, prev time.Duration) time.Duration {
+	if prev <= 0 {
+		prev = min
+	}
+	upper := prev * 3
+	if upper < min {
+		upper = min
+	}
+	delay := randBetween(min, upper)
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// randBetween returns a random duration in [min, max]. It falls back to min
+// when the range is empty or inverted.
+func randBetween(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(rand.Int64N(int64(max-min)+1))
+}
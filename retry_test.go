@@ -0,0 +1,155 @@
+package clientx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFullJitterBackoff_Bounds(t *testing.T) {
+	min := 100 * time.Millisecond
+	max := time.Second
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		for i := 0; i < 50; i++ {
+			d := FullJitterBackoff(attempt, min, max, 0)
+			if d < 0 || d > max {
+				t.Fatalf("attempt %d: delay %v out of range [0, %v]", attempt, d, max)
+			}
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackoff_Bounds(t *testing.T) {
+	min := 100 * time.Millisecond
+	max := time.Second
+
+	tests := []struct {
+		name string
+		prev time.Duration
+	}{
+		{"first attempt, prev defaults to min", 0},
+		{"prev below min still respects min floor", 10 * time.Millisecond},
+		{"prev well within range", 200 * time.Millisecond},
+		{"prev already at max", max},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				d := DecorrelatedJitterBackoff(1, min, max, tt.prev)
+				if d < min || d > max {
+					t.Fatalf("delay %v out of range [%v, %v]", d, min, max)
+				}
+			}
+		})
+	}
+}
+
+func TestRandBetween(t *testing.T) {
+	if got := randBetween(500*time.Millisecond, 100*time.Millisecond); got != 500*time.Millisecond {
+		t.Fatalf("inverted range: got %v, want min (500ms)", got)
+	}
+	for i := 0; i < 50; i++ {
+		got := randBetween(100*time.Millisecond, 200*time.Millisecond)
+		if got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("got %v, want within [100ms, 200ms]", got)
+		}
+	}
+}
+
+func TestBackoff_Next_CapsAtMaxAttempts(t *testing.T) {
+	b := &backoff{
+		minWaitTime: time.Millisecond,
+		maxWaitTime: time.Second,
+		maxAttempts: 2,
+		f:           func(n int, min, max, prev time.Duration) time.Duration { return min },
+	}
+
+	if d := b.Next(); d == stopBackoff {
+		t.Fatalf("attempt 1: got stopBackoff, want a real delay")
+	}
+	if d := b.Next(); d == stopBackoff {
+		t.Fatalf("attempt 2: got stopBackoff, want a real delay")
+	}
+	if d := b.Next(); d != stopBackoff {
+		t.Fatalf("attempt 3: got %v, want stopBackoff", d)
+	}
+}
+
+func TestBackoff_Next_MinNextDelayOverridesAndIsConsumedOnce(t *testing.T) {
+	b := &backoff{
+		minWaitTime: time.Millisecond,
+		maxWaitTime: time.Second,
+		maxAttempts: 5,
+		f:           func(n int, min, max, prev time.Duration) time.Duration { return min },
+	}
+	b.setMinNextDelay(500 * time.Millisecond)
+
+	if d := b.Next(); d != 500*time.Millisecond {
+		t.Fatalf("first Next() after setMinNextDelay = %v, want 500ms", d)
+	}
+	if d := b.Next(); d != time.Millisecond {
+		t.Fatalf("second Next() = %v, want the unmodified min delay (server hint consumed once)", d)
+	}
+}
+
+func TestBackoff_Next_MinNextDelayCappedByMax(t *testing.T) {
+	b := &backoff{
+		minWaitTime: time.Millisecond,
+		maxWaitTime: 100 * time.Millisecond,
+		maxAttempts: 5,
+		f:           func(n int, min, max, prev time.Duration) time.Duration { return min },
+	}
+	b.setMinNextDelay(time.Second)
+
+	if d := b.Next(); d != 100*time.Millisecond {
+		t.Fatalf("Next() = %v, want delay capped at maxWaitTime (100ms)", d)
+	}
+}
+
+func TestBackoff_Reset_ClearsAttemptsLastDurationAndMinNextDelay(t *testing.T) {
+	b := &backoff{
+		minWaitTime: time.Millisecond,
+		maxWaitTime: time.Second,
+		maxAttempts: 5,
+		f:           func(n int, min, max, prev time.Duration) time.Duration { return min * time.Duration(n) },
+	}
+	b.Next()
+	b.Next()
+	b.setMinNextDelay(500 * time.Millisecond)
+
+	b.Reset()
+
+	if b.Attempt() != 0 {
+		t.Fatalf("attempts after Reset = %d, want 0", b.Attempt())
+	}
+	if d := b.Next(); d != time.Millisecond {
+		t.Fatalf("first Next() after Reset = %v, want unmodified min delay (lastDuration and minNextDelay must not leak across Reset)", d)
+	}
+}
+
+func TestParseRetryAfterValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantOK  bool
+		wantMin time.Duration
+	}{
+		{"empty", "", false, 0},
+		{"seconds", "120", true, 120 * time.Second},
+		{"negative seconds rejected", "-5", false, 0},
+		{"invalid value", "not-a-date", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseRetryAfterValue(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && d != tt.wantMin {
+				t.Fatalf("d = %v, want %v", d, tt.wantMin)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package clientx
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// DoStream performs the request and, unlike DoWithDecode, skips decoding
+// entirely: it hands back the raw response body so callers can stream a
+// large download, forward it, or read it incrementally without buffering
+// the whole thing into a Go value. The caller owns the returned body and
+// must close it. Middleware registered via WithMiddleware and BeforeRequest
+// hooks still run; AfterResponse hooks, the error decoder and the client's
+// decoded-Resp callbacks are not run for streamed responses.
+func (r *RequestBuilder[Req, Resp]) DoStream(ctx context.Context) (io.ReadCloser, *http.Response, error) {
+	c := &client[Req, Resp]{api: r.api}
+
+	if err := c.api.limiter.Wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	if c.api.concurrency != nil {
+		if err := c.api.concurrency.Acquire(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	httpReq, err := c.makeRequest(ctx, r, nil)
+	if err != nil {
+		if c.api.concurrency != nil {
+			c.api.concurrency.Release(nil, err)
+		}
+		return nil, nil, err
+	}
+
+	resp, err := c.performRequest(ctx, httpReq, r)
+	c.applyRateLimitHints(resp)
+	if c.api.concurrency != nil {
+		c.api.concurrency.Release(resp, err)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return resp.Body, resp, nil
+}
+
+// DoNDJSON performs the request and decodes the response body as
+// newline-delimited JSON, invoking fn with each record as it arrives
+// instead of buffering the whole body, so it works for arbitrarily long
+// log-tailing or server-sent-event-style streams.
+func (r *RequestBuilder[Req, Resp]) DoNDJSON(ctx context.Context, fn func(*Resp) error) error {
+	body, _, err := r.DoStream(ctx)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	for {
+		var record Resp
+		if err := dec.Decode(&record); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+}
+
+// WithRequestMultipart builds a multipart/form-data request body from the
+// given form fields and files, streaming the encoded parts through an
+// io.Pipe rather than buffering them into memory, so large file uploads
+// don't need to fit in RAM.
+//
+// The pipe is single-pass: once its files have been streamed through, there
+// is no buffered copy to replay. So the body is wrapped in multipartBody,
+// which performRequest's isReplayable check refuses to retry — marking the
+// request Idempotent does not override this, since replaying it would send
+// an empty body rather than the original upload.
+func WithRequestMultipart(fields map[string]string, files map[string]io.Reader) RequestOption {
+	return func(req *http.Request) error {
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+
+		go func() {
+			err := writeMultipart(mw, fields, files)
+			closeErr := mw.Close()
+			if err == nil {
+				err = closeErr
+			}
+			_ = pw.CloseWithError(err)
+		}()
+
+		req.Body = multipartBody{pr}
+		return nil
+	}
+}
+
+// multipartBody marks a request body as the single-pass io.Pipe reader
+// produced by WithRequestMultipart, so performRequest's isReplayable check
+// can refuse to retry it instead of silently buffering an already-drained
+// pipe into an empty replay.
+type multipartBody struct {
+	io.ReadCloser
+}
+
+func writeMultipart(mw *multipart.Writer, fields map[string]string, files map[string]io.Reader) error {
+	for name, value := range fields {
+		if err := mw.WriteField(name, value); err != nil {
+			return err
+		}
+	}
+	for name, r := range files {
+		part, err := mw.CreateFormFile(name, name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
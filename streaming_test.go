@@ -0,0 +1,31 @@
+package clientx
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestWithRequestMultipart_DoesNotLeakContentTypeIntoSharedHeaders mirrors
+// the header setup makeRequest does (clone, don't alias, Options.Headers)
+// without calling makeRequest itself, and checks that on such a clone
+// WithRequestMultipart's boundary Content-Type lands only on the per-request
+// header copy, never on the shared map reused by every other request
+// through the client.
+func TestWithRequestMultipart_DoesNotLeakContentTypeIntoSharedHeaders(t *testing.T) {
+	shared := http.Header{}
+	shared.Set("Authorization", "Bearer token")
+
+	httpReq := &http.Request{Header: shared.Clone()}
+
+	opt := WithRequestMultipart(map[string]string{"field": "value"}, nil)
+	if err := opt(httpReq); err != nil {
+		t.Fatalf("WithRequestMultipart option: %v", err)
+	}
+
+	if got := httpReq.Header.Get("Content-Type"); got == "" {
+		t.Fatal("expected the per-request header to get the multipart Content-Type")
+	}
+	if got := shared.Get("Content-Type"); got != "" {
+		t.Fatalf("shared Options.Headers got a leaked Content-Type %q; it must stay untouched", got)
+	}
+}